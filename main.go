@@ -1,256 +1,257 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
-	"math"
 	"math/rand"
+	"os"
 	"strings"
 	"time"
-)
 
-type Particle struct {
-	Lifetime int64
-	Speed    float64
+	tea "github.com/charmbracelet/bubbletea"
 
-	X float64
-	Y float64
-}
+	"github.com/sakshamxkaushik/coffee_cup_ascii/render"
+	"github.com/sakshamxkaushik/coffee_cup_ascii/scene"
+	"github.com/sakshamxkaushik/coffee_cup_ascii/scenes"
+	"github.com/sakshamxkaushik/coffee_cup_ascii/simulation"
+)
 
-type ParticleParams struct {
-	MaxLife       int64
-	MaxSpeed      float64
-	ParticleCount int
-	X             int
-	Y             int
-	Scale         float64
-
-	nextPosition NextPosition
-	ascii        Ascii
-	reset        Reset
-}
+const (
+	width  = 71
+	height = 8
+	scale  = 4.5
 
-type NextPosition func(particle *Particle, deltaMs int64)
-type Ascii func(row, col int, count [][]int) string
-type Reset func(particle *Particle, params *ParticleParams)
+	frameRate = 100 * time.Millisecond
 
-type ParticleSystem struct {
-	ParticleParams
-	particles []*Particle
+	// composed is a pseudo scene name: steam and confetti layered over the
+	// cup background in one scene.Scene, rather than a single preset.
+	composed scenes.Name = "composed"
+)
 
-	lastTime int64
+type tickMsg time.Time
+
+type model struct {
+	name     scenes.Name
+	systems  []*simulation.System
+	renderer render.Renderer
+	sc       *scene.Scene
+	ascii    func(density float64) string
+	gif      *render.GIFRecorder
+	rng      *rand.Rand
+	fixedDt  time.Duration
+	env      env
 }
 
-func NewParticleSystem(params ParticleParams) ParticleSystem {
-	particles := make([]*Particle, 0)
+// env bundles the run-wide settings every model needs to rebuild itself on
+// a scene switch, so newModel doesn't grow a parameter per flag.
+type env struct {
+	renderer   render.Renderer
+	ascii      func(density float64) string
+	gif        *render.GIFRecorder
+	rng        *rand.Rand
+	fixedDt    time.Duration
+	background string
+}
 
-	for i := 0; i < params.ParticleCount; i++ {
-		particles = append(particles, &Particle{})
+func newModel(name scenes.Name, e env) model {
+	if name == composed {
+		return newComposedModel(e)
 	}
 
-	return ParticleSystem{
-		ParticleParams: params,
-		lastTime:       time.Now().UnixMilli(),
-		particles:      particles,
-	}
+	system := scenes.New(name, width, height, scale, e.rng)
+	system.Start()
+
+	return model{name: name, systems: []*simulation.System{&system}, renderer: e.renderer, rng: e.rng, fixedDt: e.fixedDt, gif: e.gif, env: e}
 }
 
-func (ps *ParticleSystem) Start() {
-	for _, p := range ps.particles {
-		ps.reset(p, &ps.ParticleParams)
+func newComposedModel(e env) model {
+	steam := scenes.NewSteam(width, height, scale, e.rng)
+	steam.Start()
+	confetti := scenes.NewConfetti(width, height, scale, e.rng)
+	confetti.Start()
+
+	bgLines := strings.Split(strings.Trim(e.background, "\n"), "\n")
+
+	sc := scene.New(width, height+len(bgLines))
+	sc.SetBackground(e.background)
+	sc.AddLayer(&steam, 0, len(bgLines), 1)
+	sc.AddLayer(&confetti, 0, len(bgLines), 2)
+
+	return model{
+		name:     composed,
+		systems:  []*simulation.System{&steam, &confetti},
+		sc:       sc,
+		renderer: e.renderer,
+		ascii:    e.ascii,
+		gif:      e.gif,
+		rng:      e.rng,
+		fixedDt:  e.fixedDt,
+		env:      e,
 	}
 }
 
-func (ps *ParticleSystem) Update() {
-	now := time.Now().UnixMilli()
-	delta := now - ps.lastTime
-	ps.lastTime = now
-
-	for _, p := range ps.particles {
-		ps.nextPosition(p, delta)
-
-		if p.Y >= float64(ps.Y) || p.X >= float64(ps.X) || p.Lifetime <= 0 {
-			ps.reset(p, &ps.ParticleParams)
-		}
-	}
+func tick() tea.Cmd {
+	return tea.Tick(frameRate, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
 }
 
-func (ps *ParticleSystem) Display() string {
-	counts := make([][]int, 0)
+func (m model) Init() tea.Cmd {
+	return tick()
+}
 
-	for row := 0; row < ps.Y; row++ {
-		count := make([]int, 0)
-		for col := 0; col < ps.X; col++ {
-			count = append(count, 0)
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "s":
+			return newModel(scenes.Steam, m.env), tick()
+		case "f":
+			return newModel(scenes.Fireworks, m.env), tick()
+		case "c":
+			return newModel(scenes.Confetti, m.env), tick()
+		case "a":
+			return newModel(composed, m.env), tick()
 		}
-		counts = append(counts, count)
-	}
-
-	for _, p := range ps.particles {
-		row := int(math.Floor(p.Y))
-		col := int(math.Round(p.X))
-
-		counts[row][col]++
-	}
-
-	out := make([][]string, 0)
-	for r, row := range counts {
-		outRow := make([]string, 0)
-		for c := range row {
-			outRow = append(outRow, ps.ascii(r, c, counts))
+	case tickMsg:
+		for _, s := range m.systems {
+			if m.fixedDt > 0 {
+				s.UpdateFixed(m.fixedDt.Milliseconds())
+			} else {
+				s.Update()
+			}
 		}
-
-		out = append(out, outRow)
-	}
-
-	reverse(out)
-	outStr := make([]string, 0)
-
-	for _, row := range out {
-		outStr = append(outStr, strings.Join(row, ""))
-	}
-
-	return strings.Join(outStr, "\n")
-}
-
-func reverse(arr [][]string) {
-	for i, j := 0, len(arr)-1; i < j; i, j = i+1, j-1 {
-		arr[i], arr[j] = arr[j], arr[i]
+		// GIFRecorder captures a single system's density field, which
+		// doesn't have a composed-scene equivalent; only record when
+		// there's exactly one layer to capture.
+		if m.gif != nil && len(m.systems) == 1 {
+			m.gif.Capture(m.systems[0])
+		}
+		return m, tick()
 	}
-}
 
-type Coffee struct {
-	ParticleSystem
+	return m, nil
 }
 
-var dirs = [][]int{
-	{-1, -1},
-	{-1, 0},
-	{-1, 1},
-	{0, -1},
-	{0, 1},
-	{1, 0},
-	{1, 1},
-	{1, -1},
-}
+func (m model) View() string {
+	help := "\n\n[s]team [f]ireworks [c]onfetti [a]ll  ·  [q]uit\n"
 
-func countParticles(row, col int, counts [][]int) int {
-	count := 0
-
-	for _, dir := range dirs {
-		r := row + dir[0]
-		c := col + dir[1]
-		if r < 0 || r >= len(counts) || c < 0 || c >= len(counts[0]) {
-			continue
+	if m.sc != nil {
+		frame := m.sc.Render(m.ascii)
+		if rec, ok := m.renderer.(*render.Recorder); ok {
+			frame = rec.RecordFrame(frame, m.sc.Width, m.sc.Height)
 		}
-		count = counts[row+dir[0]][col+dir[1]]
+		return frame + help
 	}
 
-	return count
+	return fmt.Sprintf("%s%s%s", m.renderer.Render(m.systems[0]), m.env.background, help)
 }
 
-func normalize(row, col int, counts [][]int) {
-	if countParticles(row, col, counts) > 4 {
-		counts[row][col] = 0
+func buildRenderer(name, palette string) render.Renderer {
+	p := render.ParsePalette(palette)
+
+	switch name {
+	case "ansi":
+		return render.ANSI{Palette: p}
+	case "sixel":
+		return render.Sixel{Palette: p}
+	case "plain", "":
+		return render.Plain{}
+	default:
+		log.Fatalf("unknown -renderer %q (want ansi, plain, or sixel)", name)
+		return nil
 	}
 }
 
-func reset(p *Particle, params *ParticleParams) {
-	p.Lifetime = int64(math.Floor(float64(params.MaxLife) * rand.Float64()))
-	p.Speed = params.MaxSpeed * rand.Float64()
-
-	maxX := math.Floor(float64(params.X) / 2)
-	x := math.Max(-maxX, math.Min(rand.NormFloat64()*params.Scale, maxX))
-
-	p.X = x + maxX
-	p.Y = 0
-}
-
-func nextPos(p *Particle, deltaMs int64) {
-	p.Lifetime -= deltaMs
-
-	if p.Lifetime <= 0 {
-		return
+// sceneAscii maps a density to a character the same way renderer does, for
+// scene.Scene, which composites density grids directly rather than calling
+// a Renderer. Sixel doesn't fit this per-cell model (it packs 6 rows into
+// one band across a whole grid), so composed scenes fall back to Plain's
+// shading in that case.
+func sceneAscii(rendererName, palette string) func(density float64) string {
+	if rendererName == "ansi" {
+		p := render.ParsePalette(palette)
+		return func(density float64) string {
+			r, g, b := p.At(density / render.ANSIDefaultMax)
+			return fmt.Sprintf("\x1b[38;2;%d;%d;%dm█\x1b[0m", r, g, b)
+		}
 	}
 
-	percent := (float64(deltaMs) / 2000.0)
-	p.Y += p.Speed * percent
+	return render.Shade
 }
 
-func NewCoffee(width, height int, scale float64) Coffee {
+func main() {
 	if width%2 == 0 {
 		log.Fatal("width must be odd number")
 	}
 
-	ascii := func(row, col int, counts [][]int) string {
-		count := counts[row][col]
+	rendererName := flag.String("renderer", "plain", "how to draw the particle field: ansi, plain, or sixel")
+	palette := flag.String("palette", "", "comma-separated hex colors for -renderer=ansi|sixel, e.g. fdff6a,ff718d")
+	record := flag.String("record", "", "capture the run to a file: .cast for asciicast v2, .gif for an animated GIF")
+	seed := flag.Int64("seed", 0, "seed the particle RNG for a reproducible run (0 picks a random seed and logs it)")
+	fixedDt := flag.Duration("fixed-dt", 0, "advance the simulation by this fixed timestep per tick instead of wall-clock time")
+	backgroundPath := flag.String("background", "", "path to an ASCII art file to use instead of the built-in cup")
+	flag.Parse()
 
-		if count == 0 {
-			return " "
-		}
-		if count < 4 {
-			return "░"
-		}
-		if count < 6 {
-			return "▒"
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	fmt.Fprintf(os.Stderr, "seed: %d\n", *seed)
+	rng := rand.New(rand.NewSource(*seed))
+
+	background := scenes.Cup
+	if *backgroundPath != "" {
+		data, err := os.ReadFile(*backgroundPath)
+		if err != nil {
+			log.Fatalf("reading -background file: %v", err)
 		}
-		if count < 9 {
-			return "▓"
+		background = string(data)
+	}
+
+	renderer := buildRenderer(*rendererName, *palette)
+
+	var gifRec *render.GIFRecorder
+	var recordFile *os.File
+
+	if *record != "" {
+		f, err := os.Create(*record)
+		if err != nil {
+			log.Fatalf("opening -record file: %v", err)
 		}
+		recordFile = f
 
-		return "█"
+		if strings.HasSuffix(*record, ".gif") {
+			gifRec = render.NewGIFRecorder(render.ParsePalette(*palette), frameRate)
+		} else {
+			renderer = render.NewRecorder(renderer, f)
+		}
 	}
 
-	return Coffee{
-		ParticleSystem: NewParticleSystem(
-			ParticleParams{
-				MaxLife:       7000,
-				MaxSpeed:      1.5,
-				ParticleCount: 60,
-
-				reset:        reset,
-				ascii:        ascii,
-				nextPosition: nextPos,
-				X:            width,
-				Y:            height,
-				Scale:        scale,
-			},
-		),
+	e := env{
+		renderer:   renderer,
+		ascii:      sceneAscii(*rendererName, *palette),
+		gif:        gifRec,
+		rng:        rng,
+		fixedDt:    *fixedDt,
+		background: background,
 	}
-}
 
-var cup = `
-                    .:-----====----------------:.                     
-                 .:=-===++--:::-===========+=------:                  
-                ::==+===-==:::::-:.:--:--:::--===----:                
-               .:++===:..--:::::::::::..--....:-==--+.:               
-               .=:=+==-::.::::::::::::::-:.....:===-:-=::....:        
-                :.:--=+==--::::...........:::-==+=-----. .... :.      
-              :-=   :::---==+++==------==++==---:::..=..:   .: -      
-          .-=-:.-        .....::--------::.....   ...=.+-:  :: -      
-        .=-:.....-                                ..=.=..:+-. :.      
-       -=:.......:-                              ..-::----. :=        
-      -=........  .-                            ..-:  .  ::-.=-       
-      -=......      :.                          :-.::::::.....-:      
-      --:....        .:.                      :-.::.     ....:-:      
-       ---..           ==:                  :=-          ...:--       
-        .---.           .-=-::.        .::-=-.          ..:--:        
-          -==-:.           .::--======--::.            ::-=-          
-            :-=-=-::.                             .::-=-=-            
-               ::-=:----::.......     .......::-----=-:.              
-                   .::::--:::::---------:::::--::::.                  
-                          ...::::::::::::....                        `
+	p := tea.NewProgram(newModel(scenes.Steam, e))
+	_, err := p.Run()
 
-func main() {
-	coffee := NewCoffee(71, 8, 4.5)
-	coffee.Start()
-
-	timer := time.NewTicker(100 * time.Millisecond)
-	for {
-		<-timer.C
-		fmt.Print("\033[H\033[2J")
-		coffee.Update()
-		fmt.Print(coffee.Display())
-		fmt.Print(cup)
+	if recordFile != nil {
+		if gifRec != nil {
+			if werr := gifRec.Encode(recordFile); werr != nil {
+				log.Printf("writing gif: %v", werr)
+			}
+		}
+		recordFile.Close()
+	}
+
+	if err != nil {
+		log.Fatal(err)
 	}
 }
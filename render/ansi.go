@@ -0,0 +1,47 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sakshamxkaushik/coffee_cup_ascii/simulation"
+)
+
+// ANSIDefaultMax is the density that maps to the last Palette color when
+// Max isn't set; it's tuned against the H values the scenes package uses.
+// Exported so other density-to-color call sites (e.g. a scene.Scene's
+// ascii func) can stay in sync with it instead of re-deriving the value.
+const ANSIDefaultMax = 2.2
+
+// ANSI renders the density field as solid blocks, color-interpolated
+// across Palette with 24-bit "truecolor" escapes.
+type ANSI struct {
+	Palette Palette
+	Max     float64
+}
+
+func (a ANSI) Render(s *simulation.System) string {
+	max := a.Max
+	if max <= 0 {
+		max = ANSIDefaultMax
+	}
+
+	rows := make([]string, 0, s.Y)
+	for row := 0; row < s.Y; row++ {
+		var line strings.Builder
+		for col := 0; col < s.X; col++ {
+			density := s.Density(row, col)
+			if density <= 0 {
+				line.WriteByte(' ')
+				continue
+			}
+
+			r, g, b := a.Palette.At(density / max)
+			fmt.Fprintf(&line, "\x1b[38;2;%d;%d;%dm█\x1b[0m", r, g, b)
+		}
+		rows = append(rows, line.String())
+	}
+
+	reverseStrings(rows)
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Palette is a sequence of hex RGB colors (with or without a leading '#')
+// that the color renderers interpolate across as density rises from 0 to
+// its configured max, e.g. confetty's "fdff6a,ff718d".
+type Palette []string
+
+// DefaultPalette goes from cool white steam to a warm ember, so plain
+// "-renderer=ansi" looks reasonable without a -palette flag.
+var DefaultPalette = Palette{"e8f4f8", "ff8a3d"}
+
+// ParsePalette parses a comma-separated list of hex colors as passed to
+// -palette. An empty string yields a nil Palette, which renderers treat as
+// DefaultPalette.
+func ParsePalette(s string) Palette {
+	if s == "" {
+		return nil
+	}
+
+	return Palette(strings.Split(s, ","))
+}
+
+// At interpolates the palette at t (clamped to [0, 1]), returning 8-bit
+// RGB components.
+func (p Palette) At(t float64) (r, g, b int) {
+	if len(p) == 0 {
+		p = DefaultPalette
+	}
+	if len(p) == 1 {
+		return hexColor(p[0])
+	}
+
+	t = clamp01(t)
+	segment := t * float64(len(p)-1)
+	i := int(segment)
+	if i >= len(p)-1 {
+		return hexColor(p[len(p)-1])
+	}
+
+	r0, g0, b0 := hexColor(p[i])
+	r1, g1, b1 := hexColor(p[i+1])
+	frac := segment - float64(i)
+
+	return lerp(r0, r1, frac), lerp(g0, g1, frac), lerp(b0, b1, frac)
+}
+
+func hexColor(hex string) (r, g, b int) {
+	hex = strings.TrimPrefix(hex, "#")
+	v, _ := strconv.ParseUint(hex, 16, 32)
+
+	return int(v>>16) & 0xff, int(v>>8) & 0xff, int(v) & 0xff
+}
+
+func lerp(a, b int, t float64) int {
+	return a + int(float64(b-a)*t)
+}
+
+func clamp01(t float64) float64 {
+	if t < 0 {
+		return 0
+	}
+	if t > 1 {
+		return 1
+	}
+
+	return t
+}
@@ -0,0 +1,137 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"time"
+
+	"github.com/sakshamxkaushik/coffee_cup_ascii/simulation"
+)
+
+// Recorder wraps a Renderer and appends every frame it draws to w as an
+// asciicast v2 session (https://docs.asciinema.org/manual/asciicast/v2/),
+// so a live run can be replayed later with e.g. `asciinema play`.
+type Recorder struct {
+	Renderer Renderer
+
+	w       io.Writer
+	started time.Time
+	wrote   bool
+}
+
+func NewRecorder(renderer Renderer, w io.Writer) *Recorder {
+	return &Recorder{Renderer: renderer, w: w}
+}
+
+type castHeader struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+func (r *Recorder) Render(s *simulation.System) string {
+	return r.record(r.Renderer.Render(s), s.X, s.Y)
+}
+
+// RecordFrame writes an already-rendered frame to the recording the same
+// way Render does, for callers that don't have a single simulation.System
+// to hand Renderer.Render, like a scene.Scene compositing several layers.
+func (r *Recorder) RecordFrame(frame string, width, height int) string {
+	return r.record(frame, width, height)
+}
+
+func (r *Recorder) record(frame string, width, height int) string {
+	if !r.wrote {
+		r.started = time.Now()
+		header, _ := json.Marshal(castHeader{Version: 2, Width: width, Height: height, Timestamp: r.started.Unix()})
+		fmt.Fprintf(r.w, "%s\n", header)
+		r.wrote = true
+	}
+
+	event, _ := json.Marshal([]any{time.Since(r.started).Seconds(), "o", frame + "\r\n"})
+	fmt.Fprintf(r.w, "%s\n", event)
+
+	return frame
+}
+
+// GIFRecorder accumulates each frame's density field as a paletted GIF
+// frame and writes the animation out on Encode. Unlike Recorder it doesn't
+// wrap another Renderer: a GIF needs pixels, not escape codes, so it maps
+// density to color itself via Palette.
+type GIFRecorder struct {
+	Palette Palette
+	Max     float64
+	Delay   time.Duration
+
+	anim *gif.GIF
+}
+
+func NewGIFRecorder(palette Palette, delay time.Duration) *GIFRecorder {
+	return &GIFRecorder{Palette: palette, Delay: delay, anim: &gif.GIF{}}
+}
+
+// Capture renders s's current density field into the next GIF frame. It
+// doesn't satisfy Renderer itself, since it has no text to return; callers
+// drive it alongside whichever Renderer draws the screen.
+func (g *GIFRecorder) Capture(s *simulation.System) {
+	palette := g.colorPalette()
+	img := image.NewPaletted(image.Rect(0, 0, s.X, s.Y), palette)
+
+	max := g.Max
+	if max <= 0 {
+		max = ANSIDefaultMax
+	}
+
+	for row := 0; row < s.Y; row++ {
+		for col := 0; col < s.X; col++ {
+			density := s.Density(row, col)
+			img.Set(col, s.Y-1-row, g.colorFor(density, max))
+		}
+	}
+
+	delayHundredths := int(g.Delay / (10 * time.Millisecond))
+	if delayHundredths <= 0 {
+		delayHundredths = 10
+	}
+
+	g.anim.Image = append(g.anim.Image, img)
+	g.anim.Delay = append(g.anim.Delay, delayHundredths)
+}
+
+func (g *GIFRecorder) colorPalette() color.Palette {
+	steps := 8
+	palette := make(color.Palette, 0, steps+1)
+	palette = append(palette, color.Black)
+
+	for i := 0; i < steps; i++ {
+		r, gr, b := g.Palette.At(float64(i) / float64(steps-1))
+		palette = append(palette, color.RGBA{R: uint8(r), G: uint8(gr), B: uint8(b), A: 0xff})
+	}
+
+	return palette
+}
+
+func (g *GIFRecorder) colorFor(density, max float64) color.Color {
+	if density <= 0 {
+		return color.Black
+	}
+
+	t := density / max
+	if t > 1 {
+		t = 1
+	}
+
+	r, gr, b := g.Palette.At(t)
+	return color.RGBA{R: uint8(r), G: uint8(gr), B: uint8(b), A: 0xff}
+}
+
+// Encode writes the accumulated frames as a GIF to w. Call it once after
+// the last Capture.
+func (g *GIFRecorder) Encode(w io.Writer) error {
+	return gif.EncodeAll(w, g.anim)
+}
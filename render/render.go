@@ -0,0 +1,57 @@
+// Package render turns a simulation.System's density field into terminal
+// output: the original monochrome shading, a 24-bit ANSI color gradient,
+// a sixel graphic, or a capture of any of those for later playback.
+package render
+
+import (
+	"strings"
+
+	"github.com/sakshamxkaushik/coffee_cup_ascii/simulation"
+)
+
+// Renderer draws one frame of a system's current state.
+type Renderer interface {
+	Render(s *simulation.System) string
+}
+
+func reverseStrings(rows []string) {
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+}
+
+// Plain is the original monochrome shaded-ASCII renderer.
+type Plain struct{}
+
+func (Plain) Render(s *simulation.System) string {
+	rows := make([]string, 0, s.Y)
+	for row := 0; row < s.Y; row++ {
+		var line strings.Builder
+		for col := 0; col < s.X; col++ {
+			line.WriteString(Shade(s.Density(row, col)))
+		}
+		rows = append(rows, line.String())
+	}
+
+	reverseStrings(rows)
+	return strings.Join(rows, "\n")
+}
+
+// Shade maps a density to the monochrome shading ramp. It's also the
+// default character mapping a scene.Scene uses to composite layers.
+func Shade(density float64) string {
+	if density <= 0 {
+		return " "
+	}
+	if density < 0.5 {
+		return "░"
+	}
+	if density < 1.2 {
+		return "▒"
+	}
+	if density < 2.2 {
+		return "▓"
+	}
+
+	return "█"
+}
@@ -0,0 +1,97 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sakshamxkaushik/coffee_cup_ascii/simulation"
+)
+
+// sixelLevels is the number of density buckets (and color registers) the
+// Sixel renderer quantizes Palette into.
+const sixelLevels = 5
+
+// Sixel renders the density field as a DECSIXEL graphic, for terminals
+// that support inline images (wezterm, xterm -ti vt340, mlterm). It
+// quantizes Palette into sixelLevels registers and emits one 6-row band
+// at a time, which is the native unit of the sixel format.
+type Sixel struct {
+	Palette Palette
+	Max     float64
+}
+
+func (sx Sixel) Render(s *simulation.System) string {
+	max := sx.Max
+	if max <= 0 {
+		max = ANSIDefaultMax
+	}
+
+	levels := make([][]int, s.Y)
+	for row := range levels {
+		levels[row] = make([]int, s.X)
+		for col := 0; col < s.X; col++ {
+			levels[row][col] = sx.bucket(s.Density(row, col), max)
+		}
+	}
+	reverseRows(levels)
+
+	var b strings.Builder
+	b.WriteString("\x1bPq")
+
+	for reg := 0; reg < sixelLevels; reg++ {
+		r, g, bl := sx.Palette.At(float64(reg) / float64(sixelLevels-1))
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", reg, pct(r), pct(g), pct(bl))
+	}
+
+	for band := 0; band < s.Y; band += 6 {
+		for reg := 0; reg < sixelLevels; reg++ {
+			fmt.Fprintf(&b, "#%d", reg)
+			for col := 0; col < s.X; col++ {
+				bits := 0
+				for k := 0; k < 6; k++ {
+					row := band + k
+					if row < s.Y && levels[row][col] == reg {
+						bits |= 1 << uint(k)
+					}
+				}
+				b.WriteByte(byte(63 + bits))
+			}
+			b.WriteString("$")
+		}
+		b.WriteString("-")
+	}
+
+	b.WriteString("\x1b\\")
+	return b.String()
+}
+
+// bucket maps a density (0..max) to one of sixelLevels registers, with 0
+// reserved for "no particle here".
+func (sx Sixel) bucket(density, max float64) int {
+	if density <= 0 {
+		return 0
+	}
+
+	t := density / max
+	if t > 1 {
+		t = 1
+	}
+
+	level := 1 + int(t*float64(sixelLevels-2))
+	if level >= sixelLevels {
+		level = sixelLevels - 1
+	}
+
+	return level
+}
+
+func reverseRows(rows [][]int) {
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+}
+
+// pct converts an 8-bit color component to sixel's 0-100 percentage scale.
+func pct(v int) int {
+	return (v*100 + 127) / 255
+}
@@ -0,0 +1,192 @@
+// Package scenes holds the preset particle scenes (steam, fireworks,
+// confetti) the TUI can switch between, plus the static cup art.
+package scenes
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/sakshamxkaushik/coffee_cup_ascii/simulation"
+)
+
+// rng returns r, or a randomly seeded source if r is nil, so every scene
+// constructor works whether or not the caller cares about reproducibility.
+func rng(r *rand.Rand) *rand.Rand {
+	if r != nil {
+		return r
+	}
+
+	return rand.New(rand.NewSource(rand.Int63()))
+}
+
+// Name identifies a preset scene the user can switch to.
+type Name string
+
+const (
+	Steam     Name = "steam"
+	Fireworks Name = "fireworks"
+	Confetti  Name = "confetti"
+)
+
+var All = []Name{Steam, Fireworks, Confetti}
+
+// NewSteam builds the original rising-steam particle system, now drifting
+// sideways on a gentle Wind on top of its upward drift.
+func NewSteam(width, height int, scale float64, r *rand.Rand) simulation.System {
+	reset := func(p *simulation.Particle, params *simulation.Params) {
+		p.Lifetime = int64(math.Floor(float64(params.MaxLife) * params.Rand.Float64()))
+		p.Speed = params.MaxSpeed * params.Rand.Float64()
+
+		maxX := math.Floor(float64(params.X) / 2)
+		x := math.Max(-maxX, math.Min(params.Rand.NormFloat64()*params.Scale, maxX))
+
+		p.X = x + maxX
+		p.Y = 0
+	}
+
+	nextPos := func(p *simulation.Particle, deltaMs int64) {
+		p.Lifetime -= deltaMs
+
+		if p.Lifetime <= 0 {
+			return
+		}
+
+		percent := float64(deltaMs) / 2000.0
+		p.Y += p.Speed * percent
+	}
+
+	return simulation.New(simulation.Params{
+		MaxLife:       7000,
+		MaxSpeed:      1.5,
+		ParticleCount: 60,
+		H:             1.2,
+		Rand:          rng(r),
+
+		Wind: simulation.Vector{X: 0.4},
+
+		Reset:        reset,
+		NextPosition: nextPos,
+		X:            width,
+		Y:            height,
+		Scale:        scale,
+	})
+}
+
+// fireworksMaxLife is NewFireworks's Params.MaxLife, kept as a constant so
+// nextPos can use it to taper off the initial launch speed without a
+// closure over params.
+const fireworksMaxLife = int64(1500)
+
+// NewFireworks bursts particles upward from the bottom center and lets
+// Gravity pull them back down, for a shell-burst look above the cup. The
+// launch speed set at reset decays over each particle's lifetime, so Wind
+// and Gravity increasingly dominate and the shell arcs back down instead
+// of flying out at a constant rate.
+func NewFireworks(width, height int, scale float64, r *rand.Rand) simulation.System {
+	reset := func(p *simulation.Particle, params *simulation.Params) {
+		p.Lifetime = int64(math.Floor(float64(params.MaxLife) * params.Rand.Float64()))
+		p.Speed = params.MaxSpeed * (0.5 + params.Rand.Float64())
+
+		maxX := math.Floor(float64(params.X) / 2)
+		x := math.Max(-maxX, math.Min(params.Rand.NormFloat64()*params.Scale, maxX))
+
+		p.X = x + maxX
+		p.Y = 0
+	}
+
+	nextPos := func(p *simulation.Particle, deltaMs int64) {
+		p.Lifetime -= deltaMs
+
+		if p.Lifetime <= 0 {
+			return
+		}
+
+		percent := float64(deltaMs) / 1000.0
+		launch := float64(p.Lifetime) / float64(fireworksMaxLife)
+		p.Y += p.Speed * launch * percent
+	}
+
+	return simulation.New(simulation.Params{
+		MaxLife:       fireworksMaxLife,
+		MaxSpeed:      6,
+		ParticleCount: 80,
+		H:             1.2,
+		Rand:          rng(r),
+
+		Gravity: simulation.Vector{Y: -1.2},
+
+		Reset:        reset,
+		NextPosition: nextPos,
+		X:            width,
+		Y:            height,
+		Scale:        scale,
+	})
+}
+
+// NewConfetti drops particles from the top, letting Gravity carry them
+// down into the cup.
+func NewConfetti(width, height int, scale float64, r *rand.Rand) simulation.System {
+	reset := func(p *simulation.Particle, params *simulation.Params) {
+		p.Lifetime = int64(math.Floor(float64(params.MaxLife) * params.Rand.Float64()))
+		p.Speed = params.MaxSpeed * params.Rand.Float64()
+		p.X = params.Rand.Float64() * float64(params.X)
+		p.Y = float64(params.Y)
+	}
+
+	nextPos := func(p *simulation.Particle, deltaMs int64) {
+		p.Lifetime -= deltaMs
+
+		if p.Lifetime <= 0 {
+			return
+		}
+	}
+
+	return simulation.New(simulation.Params{
+		MaxLife:       4000,
+		MaxSpeed:      1,
+		ParticleCount: 40,
+		H:             1.2,
+		Rand:          rng(r),
+
+		Gravity: simulation.Vector{Y: -3},
+
+		Reset:        reset,
+		NextPosition: nextPos,
+		X:            width,
+		Y:            height,
+		Scale:        scale,
+	})
+}
+
+func New(name Name, width, height int, scale float64, r *rand.Rand) simulation.System {
+	switch name {
+	case Fireworks:
+		return NewFireworks(width, height, scale, r)
+	case Confetti:
+		return NewConfetti(width, height, scale, r)
+	default:
+		return NewSteam(width, height, scale, r)
+	}
+}
+
+var Cup = `
+                    .:-----====----------------:.                     
+                 .:=-===++--:::-===========+=------:                  
+                ::==+===-==:::::-:.:--:--:::--===----:                
+               .:++===:..--:::::::::::..--....:-==--+.:               
+               .=:=+==-::.::::::::::::::-:.....:===-:-=::....:        
+                :.:--=+==--::::...........:::-==+=-----. .... :.      
+              :-=   :::---==+++==------==++==---:::..=..:   .: -      
+          .-=-:.-        .....::--------::.....   ...=.+-:  :: -      
+        .=-:.....-                                ..=.=..:+-. :.      
+       -=:.......:-                              ..-::----. :=        
+      -=........  .-                            ..-:  .  ::-.=-       
+      -=......      :.                          :-.::::::.....-:      
+      --:....        .:.                      :-.::.     ....:-:      
+       ---..           ==:                  :=-          ...:--       
+        .---.           .-=-::.        .::-=-.          ..:--:        
+          -==-:.           .::--======--::.            ::-=-          
+            :-=-=-::.                             .::-=-=-            
+               ::-=:----::.......     .......::-----=-:.              
+                   .::::--:::::---------:::::--::::.                  
+                          ...::::::::::::....                        `
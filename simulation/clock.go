@@ -0,0 +1,16 @@
+package simulation
+
+import "time"
+
+// Clock abstracts "now" so System.Update is testable without depending on
+// wall-clock time.
+type Clock interface {
+	NowMilli() int64
+}
+
+// WallClock is the Clock System uses unless Params.Clock is set.
+type WallClock struct{}
+
+func (WallClock) NowMilli() int64 {
+	return time.Now().UnixMilli()
+}
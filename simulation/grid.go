@@ -0,0 +1,57 @@
+package simulation
+
+import "math"
+
+// cell identifies a uniform-grid bucket.
+type cell struct {
+	x int
+	y int
+}
+
+// spatialHash buckets particles into uniform cells of side cellSize, so
+// Neighbors can look up nearby particles without scanning the whole
+// system. It's rebuilt from scratch every frame in System.advance.
+type spatialHash struct {
+	cellSize float64
+	buckets  map[cell][]*Particle
+}
+
+func newSpatialHash(cellSize float64, particles []*Particle) *spatialHash {
+	h := &spatialHash{
+		cellSize: cellSize,
+		buckets:  make(map[cell][]*Particle, len(particles)),
+	}
+
+	for _, p := range particles {
+		c := h.cellOf(p.X, p.Y)
+		h.buckets[c] = append(h.buckets[c], p)
+	}
+
+	return h
+}
+
+func (h *spatialHash) cellOf(x, y float64) cell {
+	return cell{
+		x: int(math.Floor(x / h.cellSize)),
+		y: int(math.Floor(y / h.cellSize)),
+	}
+}
+
+// Neighbors returns every particle within radius of (x, y).
+func (h *spatialHash) Neighbors(x, y, radius float64) []*Particle {
+	center := h.cellOf(x, y)
+	reach := int(math.Ceil(radius / h.cellSize))
+
+	neighbors := make([]*Particle, 0)
+	for dx := -reach; dx <= reach; dx++ {
+		for dy := -reach; dy <= reach; dy++ {
+			for _, p := range h.buckets[cell{x: center.x + dx, y: center.y + dy}] {
+				if dist := math.Hypot(p.X-x, p.Y-y); dist <= radius {
+					neighbors = append(neighbors, p)
+				}
+			}
+		}
+	}
+
+	return neighbors
+}
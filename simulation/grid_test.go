@@ -0,0 +1,46 @@
+package simulation
+
+import "testing"
+
+func TestSpatialHashNeighbors(t *testing.T) {
+	near := &Particle{X: 5, Y: 5}
+	alsoNear := &Particle{X: 5.5, Y: 5.5}
+	far := &Particle{X: 20, Y: 20}
+
+	h := newSpatialHash(1.2, []*Particle{near, alsoNear, far})
+
+	got := h.Neighbors(5, 5, 1)
+
+	if len(got) != 2 {
+		t.Fatalf("Neighbors(5, 5, 1) returned %d particles, want 2: %v", len(got), got)
+	}
+
+	for _, p := range got {
+		if p == far {
+			t.Fatalf("Neighbors(5, 5, 1) included a particle outside the radius: %+v", p)
+		}
+	}
+}
+
+func TestSpatialHashNeighborsEmptyCell(t *testing.T) {
+	h := newSpatialHash(1.2, nil)
+
+	if got := h.Neighbors(0, 0, 5); len(got) != 0 {
+		t.Fatalf("Neighbors on an empty hash returned %d particles, want 0", len(got))
+	}
+}
+
+func TestSpatialHashNeighborsSpansCellBoundary(t *testing.T) {
+	// These two particles sit in adjacent cells (cellSize 1) but within 1
+	// unit of each other, so a search must check neighboring buckets, not
+	// just the center cell.
+	a := &Particle{X: 0.9, Y: 0}
+	b := &Particle{X: 1.1, Y: 0}
+
+	h := newSpatialHash(1, []*Particle{a, b})
+
+	got := h.Neighbors(0.9, 0, 0.3)
+	if len(got) != 2 {
+		t.Fatalf("Neighbors across a cell boundary returned %d particles, want 2", len(got))
+	}
+}
@@ -0,0 +1,15 @@
+package simulation
+
+import "math"
+
+// wendland evaluates the 2D Wendland C2 smoothing kernel at q = r/h,
+// normalized so that integrating it over the plane gives 1. It's smooth,
+// compactly supported (zero for q > 1), and cheap relative to a Gaussian.
+func wendland(q, h float64) float64 {
+	if q > 1 {
+		return 0
+	}
+
+	alpha := 7 / (math.Pi * h * h)
+	return alpha * math.Pow(1-q, 4) * (1 + 4*q)
+}
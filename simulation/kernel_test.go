@@ -0,0 +1,41 @@
+package simulation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWendland(t *testing.T) {
+	const h = 1.2
+
+	cases := []struct {
+		name string
+		q    float64
+		want float64
+	}{
+		{"center", 0, 7 / (math.Pi * h * h)},
+		{"edge of support", 1, 0},
+		{"beyond support", 1.5, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := wendland(c.q, h); math.Abs(got-c.want) > 1e-9 {
+				t.Errorf("wendland(%v, %v) = %v, want %v", c.q, h, got, c.want)
+			}
+		})
+	}
+}
+
+func TestWendlandDecreasesWithQ(t *testing.T) {
+	const h = 1.2
+
+	prev := wendland(0, h)
+	for q := 0.1; q <= 1; q += 0.1 {
+		got := wendland(q, h)
+		if got > prev {
+			t.Fatalf("wendland(%v, %v) = %v, want <= previous value %v", q, h, got, prev)
+		}
+		prev = got
+	}
+}
@@ -0,0 +1,157 @@
+// Package simulation implements a small reusable particle engine shared by
+// the various ASCII scenes (steam, fireworks, confetti, ...).
+package simulation
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+type Particle struct {
+	Lifetime int64
+	Speed    float64
+
+	X float64
+	Y float64
+}
+
+// Vector is a 2D force/velocity vector, e.g. for Wind or Gravity.
+type Vector struct {
+	X float64
+	Y float64
+}
+
+type NextPosition func(p *Particle, deltaMs int64)
+type Reset func(p *Particle, params *Params)
+
+// Force computes an additional velocity (units per second) for p, which is
+// scaled by dt seconds and applied on top of whatever NextPosition already
+// did and whatever Wind/Gravity contribute. It's optional: leave it nil for a scene that only needs
+// Wind/Gravity, which apply every frame regardless.
+type Force func(p *Particle, dt float64) Vector
+
+type Params struct {
+	MaxLife       int64
+	MaxSpeed      float64
+	ParticleCount int
+	X             int
+	Y             int
+	Scale         float64
+
+	// H is the SPH smoothing length used by Density/Neighbors: larger
+	// values blend more particles together for a smoother, blurrier field.
+	H float64
+
+	// Wind and Gravity are added to Force's result (or to zero, if Force is
+	// nil) and applied to every particle every frame.
+	Wind    Vector
+	Gravity Vector
+
+	// Clock defaults to WallClock. Rand defaults to a time-seeded source.
+	// Set both explicitly (e.g. with a fixed seed) for reproducible runs.
+	Clock Clock
+	Rand  *rand.Rand
+
+	NextPosition NextPosition
+	Reset        Reset
+	Force        Force
+}
+
+// System is a reusable particle simulation: a fixed pool of particles
+// advanced by Params.NextPosition (and, optionally, Params.Force). Its
+// Density method exposes the smoothed SPH field a render.Renderer draws
+// from.
+type System struct {
+	Params
+	Particles []*Particle
+
+	lastTime int64
+	grid     *spatialHash
+}
+
+func New(params Params) System {
+	if params.Clock == nil {
+		params.Clock = WallClock{}
+	}
+	if params.Rand == nil {
+		params.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	particles := make([]*Particle, 0, params.ParticleCount)
+
+	for i := 0; i < params.ParticleCount; i++ {
+		particles = append(particles, &Particle{})
+	}
+
+	return System{
+		Params:    params,
+		lastTime:  params.Clock.NowMilli(),
+		Particles: particles,
+	}
+}
+
+func (s *System) Start() {
+	for _, p := range s.Particles {
+		s.Reset(p, &s.Params)
+	}
+}
+
+func (s *System) Update() {
+	now := s.Clock.NowMilli()
+	delta := now - s.lastTime
+	s.lastTime = now
+
+	s.advance(delta)
+}
+
+// UpdateFixed advances the system by a constant deltaMs, ignoring wall-clock
+// time. Callers that need reproducible output (tests, fixed-timestep runs)
+// call this instead of Update.
+func (s *System) UpdateFixed(deltaMs int64) {
+	s.advance(deltaMs)
+}
+
+func (s *System) advance(deltaMs int64) {
+	for _, p := range s.Particles {
+		s.NextPosition(p, deltaMs)
+
+		dt := float64(deltaMs) / 1000.0
+		f := Vector{}
+		if s.Force != nil {
+			f = s.Force(p, dt)
+		}
+		p.X += (f.X + s.Wind.X + s.Gravity.X) * dt
+		p.Y += (f.Y + s.Wind.Y + s.Gravity.Y) * dt
+
+		if p.Y >= float64(s.Y) || p.X >= float64(s.X) || p.X < 0 || p.Lifetime <= 0 {
+			s.Reset(p, &s.Params)
+		}
+	}
+
+	s.grid = newSpatialHash(s.H, s.Particles)
+}
+
+// Neighbors returns the particles within radius of (x, y), using the
+// spatial hash rebuilt on the most recent Update/UpdateFixed call.
+func (s *System) Neighbors(x, y, radius float64) []*Particle {
+	if s.grid == nil {
+		s.grid = newSpatialHash(s.H, s.Particles)
+	}
+
+	return s.grid.Neighbors(x, y, radius)
+}
+
+// Density returns the smoothed SPH density at the grid cell (row, col),
+// summing the Wendland kernel over every particle within H of its center.
+func (s *System) Density(row, col int) float64 {
+	x, y := float64(col), float64(row)
+
+	density := 0.0
+	for _, p := range s.Neighbors(x, y, s.H) {
+		r := math.Hypot(p.X-x, p.Y-y)
+		density += wendland(r/s.H, s.H)
+	}
+
+	return density
+}
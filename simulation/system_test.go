@@ -0,0 +1,76 @@
+package simulation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+const (
+	goldenFrames = 30
+	goldenDtMs   = 100
+
+	// goldenHash was generated once by running this test against the Reset/
+	// NextPosition below with a fixed seed; a change to the simulation or
+	// the SPH kernel is expected to change it. If you made such a change on
+	// purpose, regenerate it and update this constant.
+	goldenHash = "78a21d034f34d8186cfaa21a4b3c1b1003737801730463cd8aa06adb425298ff"
+)
+
+func goldenSystem() System {
+	params := Params{
+		MaxLife:       7000,
+		MaxSpeed:      1.5,
+		ParticleCount: 20,
+		X:             21,
+		Y:             8,
+		Scale:         4.5,
+		H:             1.2,
+		Rand:          rand.New(rand.NewSource(42)),
+	}
+
+	params.Reset = func(p *Particle, params *Params) {
+		p.Lifetime = int64(float64(params.MaxLife) * params.Rand.Float64())
+		p.Speed = params.MaxSpeed * params.Rand.Float64()
+		p.X = params.Rand.Float64() * float64(params.X)
+		p.Y = 0
+	}
+
+	params.NextPosition = func(p *Particle, deltaMs int64) {
+		p.Lifetime -= deltaMs
+
+		if p.Lifetime <= 0 {
+			return
+		}
+
+		p.Y += p.Speed * (float64(deltaMs) / 2000.0)
+	}
+
+	return New(params)
+}
+
+// TestParticleSystem_Golden hashes the density field across a fixed number
+// of fixed-timestep frames and compares it to a checked-in fixture, so a
+// seeded run stays reproducible across changes elsewhere in the codebase.
+func TestParticleSystem_Golden(t *testing.T) {
+	sys := goldenSystem()
+	sys.Start()
+
+	h := sha256.New()
+	for i := 0; i < goldenFrames; i++ {
+		sys.UpdateFixed(goldenDtMs)
+
+		for row := 0; row < sys.Y; row++ {
+			for col := 0; col < sys.X; col++ {
+				fmt.Fprintf(h, "%.6f,", sys.Density(row, col))
+			}
+		}
+	}
+
+	got := hex.EncodeToString(h.Sum(nil))
+	if got != goldenHash {
+		t.Fatalf("golden hash mismatch: got %s, want %s (update goldenHash if this change was intentional)", got, goldenHash)
+	}
+}